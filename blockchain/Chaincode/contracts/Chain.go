@@ -3,7 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"time"
+	"os"
 
 	"github.com/hyperledger/fabric-contract-api-go/contractapi"
 )
@@ -19,28 +19,284 @@ type ParkingSlot struct {
 	Timestamp string `json:"timestamp"`
 }
 
-func (s *SmartContracts) UpdateStatus(ctx contractapi.TransactionContextInterface, id string, occupied bool, location string) error {
+// InitLedger seeds the ledger with a starting set of parking slots so the
+// network has usable state immediately after deployment.
+func (s *SmartContract) InitLedger(ctx contractapi.TransactionContextInterface) error {
+	if err := authorize(ctx, roleAdmin); err != nil {
+		return err
+	}
+
+	now, err := txTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	slots := []ParkingSlot{
+		{ID: "SLOT0", Location: "Level1-A1", Occupied: false, Timestamp: now},
+		{ID: "SLOT1", Location: "Level1-A2", Occupied: false, Timestamp: now},
+		{ID: "SLOT2", Location: "Level1-A3", Occupied: false, Timestamp: now},
+	}
+
+	for _, slot := range slots {
+		slotJSON, err := json.Marshal(slot)
+		if err != nil {
+			return fmt.Errorf("failed to marshal slot %s: %w", slot.ID, err)
+		}
+		if err := ctx.GetStub().PutState(slot.ID, slotJSON); err != nil {
+			return fmt.Errorf("failed to put slot %s to world state: %w", slot.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// CreateSlot adds a new parking slot to the ledger. It fails if a slot with
+// the same ID already exists.
+func (s *SmartContract) CreateSlot(ctx contractapi.TransactionContextInterface, id string, location string) error {
+	if err := authorize(ctx, roleAdmin); err != nil {
+		return err
+	}
+
+	exists, err := s.SlotExists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("slot %s already exists", id)
+	}
+
+	now, err := txTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
 	slot := ParkingSlot{
 		ID:        id,
-		Location:  location, 
+		Location:  location,
+		Occupied:  false,
+		Timestamp: now,
+	}
+
+	slotJSON, err := json.Marshal(slot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal slot %s: %w", id, err)
+	}
+
+	return ctx.GetStub().PutState(id, slotJSON)
+}
+
+// ReadSlot returns the parking slot stored for the given ID.
+func (s *SmartContract) ReadSlot(ctx contractapi.TransactionContextInterface, id string) (*ParkingSlot, error) {
+	slotJSON, err := ctx.GetStub().GetState(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read slot %s from world state: %w", id, err)
+	}
+	if slotJSON == nil {
+		return nil, fmt.Errorf("slot %s does not exist", id)
+	}
+
+	var slot ParkingSlot
+	if err := json.Unmarshal(slotJSON, &slot); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal slot %s: %w", id, err)
+	}
+
+	return &slot, nil
+}
+
+// UpdateSlot overwrites an existing slot's location and occupied status. It
+// fails if the slot does not already exist.
+func (s *SmartContract) UpdateSlot(ctx contractapi.TransactionContextInterface, id string, location string, occupied bool) error {
+	if err := authorize(ctx, roleSensor); err != nil {
+		return err
+	}
+
+	exists, err := s.SlotExists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("slot %s does not exist", id)
+	}
+
+	now, err := txTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	slot := ParkingSlot{
+		ID:        id,
+		Location:  location,
 		Occupied:  occupied,
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: now,
 	}
-	slotJSON, _ := json.Marshal(slot)
+
+	slotJSON, err := json.Marshal(slot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal slot %s: %w", id, err)
+	}
+
 	return ctx.GetStub().PutState(id, slotJSON)
 }
 
+// DeleteSlot removes a parking slot from the ledger. It fails if the slot
+// does not exist.
+func (s *SmartContract) DeleteSlot(ctx contractapi.TransactionContextInterface, id string) error {
+	if err := authorize(ctx, roleAdmin); err != nil {
+		return err
+	}
+
+	exists, err := s.SlotExists(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("slot %s does not exist", id)
+	}
+
+	return ctx.GetStub().DelState(id)
+}
+
+// SlotExists returns true when a slot with the given ID is present in the
+// world state.
+func (s *SmartContract) SlotExists(ctx contractapi.TransactionContextInterface, id string) (bool, error) {
+	slotJSON, err := ctx.GetStub().GetState(id)
+	if err != nil {
+		return false, fmt.Errorf("failed to read slot %s from world state: %w", id, err)
+	}
+
+	return slotJSON != nil, nil
+}
+
+// UpdateStatus is kept for callers that only want to flip occupancy and
+// location without separately managing slot lifecycle; it upserts the slot.
+func (s *SmartContract) UpdateStatus(ctx contractapi.TransactionContextInterface, id string, occupied bool, location string) error {
+	if err := authorize(ctx, roleSensor); err != nil {
+		return err
+	}
+
+	now, err := txTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	slot := ParkingSlot{
+		ID:        id,
+		Location:  location,
+		Occupied:  occupied,
+		Timestamp: now,
+	}
+
+	slotJSON, err := json.Marshal(slot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal slot %s: %w", id, err)
+	}
+
+	if err := ctx.GetStub().PutState(id, slotJSON); err != nil {
+		return err
+	}
+
+	return emitSlotStatusChanged(ctx, slot)
+}
+
+// Occupy marks an existing, currently vacant slot as occupied. It rejects
+// the transition if the slot is already occupied. It is the sensor-facing
+// entry point; CheckIn drives the same transition for reservation check-ins.
+func (s *SmartContract) Occupy(ctx contractapi.TransactionContextInterface, id string) error {
+	if err := authorize(ctx, roleSensor); err != nil {
+		return err
+	}
+
+	return s.occupySlot(ctx, id)
+}
+
+// occupySlot performs the Occupy state transition without checking the
+// caller's role, so callers with their own authorization rules (e.g.
+// CheckIn) can reuse it.
+func (s *SmartContract) occupySlot(ctx contractapi.TransactionContextInterface, id string) error {
+	slot, err := s.ReadSlot(ctx, id)
+	if err != nil {
+		return err
+	}
+	if slot.Occupied {
+		return fmt.Errorf("slot %s is already occupied", id)
+	}
+
+	now, err := txTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	slot.Occupied = true
+	slot.Timestamp = now
+
+	slotJSON, err := json.Marshal(slot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal slot %s: %w", id, err)
+	}
+
+	if err := ctx.GetStub().PutState(id, slotJSON); err != nil {
+		return err
+	}
+
+	return emitSlotStatusChanged(ctx, *slot)
+}
+
+// Release marks an existing, currently occupied slot as vacant. It rejects
+// the transition if the slot is not occupied.
+func (s *SmartContract) Release(ctx contractapi.TransactionContextInterface, id string) error {
+	if err := authorize(ctx, roleSensor); err != nil {
+		return err
+	}
+
+	slot, err := s.ReadSlot(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !slot.Occupied {
+		return fmt.Errorf("slot %s is not occupied", id)
+	}
+
+	now, err := txTimestamp(ctx)
+	if err != nil {
+		return err
+	}
+
+	slot.Occupied = false
+	slot.Timestamp = now
+
+	slotJSON, err := json.Marshal(slot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal slot %s: %w", id, err)
+	}
+
+	if err := ctx.GetStub().PutState(id, slotJSON); err != nil {
+		return err
+	}
+
+	return emitSlotStatusChanged(ctx, *slot)
+}
+
 func (s *SmartContract) GetAllSlots(ctx contractapi.TransactionContextInterface) ([]ParkingSlot, error) {
-	resultsIterator, _ := ctx.GetStub().GetStateByRange("", "")
+	resultsIterator, err := ctx.GetStub().GetStateByRange("", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state range: %w", err)
+	}
 	defer resultsIterator.Close()
 
 	var slots []ParkingSlot
 	for resultsIterator.HasNext() {
-		queryResponse, _ := resultsIterator.Next()
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate state range: %w", err)
+		}
+
 		var slot ParkingSlot
-		json.Unmarshal(queryResponse.Value, &slot)
+		if err := json.Unmarshal(queryResponse.Value, &slot); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal slot %s: %w", queryResponse.Key, err)
+		}
 		slots = append(slots, slot)
 	}
+
 	return slots, nil
 }
 
@@ -50,6 +306,12 @@ func main() {
 		fmt.Printf("Error create parking chaincode: %s", err.Error())
 		return
 	}
+
+	if serverAddress := os.Getenv("CHAINCODE_SERVER_ADDRESS"); serverAddress != "" {
+		startAsServer(chaincode, serverAddress)
+		return
+	}
+
 	if err := chaincode.Start(); err != nil {
 		fmt.Printf("Error starting parking chaincode: %s", err.Error())
 	}
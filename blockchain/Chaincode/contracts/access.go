@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+const (
+	// roleSensor identifies identities allowed to report occupancy changes.
+	roleSensor = "sensor"
+	// roleAdmin identifies identities allowed to manage slot lifecycle.
+	roleAdmin = "admin"
+	// sensorOrgMSP is the MSP trusted to report occupancy changes without
+	// needing the "role" attribute set, e.g. a dedicated sensor-gateway org.
+	sensorOrgMSP = "SensorOrgMSP"
+)
+
+// authorize checks that the invoking client identity carries a "role"
+// attribute matching one of requiredRoles, or - for roleSensor - belongs to
+// sensorOrgMSP. Readers are intentionally not routed through this helper.
+func authorize(ctx contractapi.TransactionContextInterface, requiredRoles ...string) error {
+	clientIdentity := ctx.GetClientIdentity()
+
+	for _, role := range requiredRoles {
+		if role == roleSensor {
+			mspID, err := clientIdentity.GetMSPID()
+			if err != nil {
+				return fmt.Errorf("failed to get client MSP ID: %w", err)
+			}
+			if mspID == sensorOrgMSP {
+				return nil
+			}
+		}
+
+		value, found, err := clientIdentity.GetAttributeValue("role")
+		if err != nil {
+			return fmt.Errorf("failed to read role attribute: %w", err)
+		}
+		if found && value == role {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("client identity is not authorized for this operation, requires role in %v", requiredRoles)
+}
+
+// authorizeDriverOrAdmin checks that the invoking client identity either is
+// the driver in question (its client ID matches driverID) or carries the
+// admin role, so a driver can manage their own reservations without sensor
+// credentials while staff retain an override to act on a driver's behalf.
+func authorizeDriverOrAdmin(ctx contractapi.TransactionContextInterface, driverID string) error {
+	clientID, err := ctx.GetClientIdentity().GetID()
+	if err != nil {
+		return fmt.Errorf("failed to get client ID: %w", err)
+	}
+	if clientID == driverID {
+		return nil
+	}
+
+	if err := authorize(ctx, roleAdmin); err != nil {
+		return fmt.Errorf("client identity is not authorized to act on behalf of driver %s: %w", driverID, err)
+	}
+
+	return nil
+}
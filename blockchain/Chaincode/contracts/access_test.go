@@ -0,0 +1,84 @@
+package main
+
+import "testing"
+
+func TestUpdateStatusRejectsUnauthorizedCaller(t *testing.T) {
+	contract := new(SmartContract)
+	adminCtx := newTestContext(t, roleAdmin)
+
+	if err := contract.CreateSlot(adminCtx, "SLOT5", "Level1-C1"); err != nil {
+		t.Fatalf("CreateSlot returned error: %v", err)
+	}
+
+	readerCtx := newTestContext(t, "")
+	readerCtx.SetStub(adminCtx.GetStub())
+	if err := contract.UpdateStatus(readerCtx, "SLOT5", true, "Level1-C1"); err == nil {
+		t.Fatal("expected UpdateStatus to reject a caller without the sensor role")
+	}
+
+	slot, err := contract.ReadSlot(adminCtx, "SLOT5")
+	if err != nil {
+		t.Fatalf("ReadSlot returned error: %v", err)
+	}
+	if slot.Occupied {
+		t.Fatal("expected no state to be written by the rejected UpdateStatus call")
+	}
+}
+
+func TestOccupyAndUpdateSlotRejectUnauthorizedCaller(t *testing.T) {
+	contract := new(SmartContract)
+	adminCtx := newTestContext(t, roleAdmin)
+
+	if err := contract.CreateSlot(adminCtx, "SLOT6", "Level1-C2"); err != nil {
+		t.Fatalf("CreateSlot returned error: %v", err)
+	}
+
+	readerCtx := newTestContext(t, "")
+	readerCtx.SetStub(adminCtx.GetStub())
+	if err := contract.Occupy(readerCtx, "SLOT6"); err == nil {
+		t.Fatal("expected Occupy to reject a caller without the sensor role")
+	}
+	if err := contract.UpdateSlot(readerCtx, "SLOT6", "Level1-C2", true); err == nil {
+		t.Fatal("expected UpdateSlot to reject a caller without the sensor role")
+	}
+
+	slot, err := contract.ReadSlot(adminCtx, "SLOT6")
+	if err != nil {
+		t.Fatalf("ReadSlot returned error: %v", err)
+	}
+	if slot.Occupied {
+		t.Fatal("expected no state to be written by the rejected Occupy/UpdateSlot calls")
+	}
+}
+
+func TestDeleteSlotRequiresAdminRole(t *testing.T) {
+	contract := new(SmartContract)
+	adminCtx := newTestContext(t, roleAdmin)
+
+	if err := contract.CreateSlot(adminCtx, "SLOT7", "Level1-C3"); err != nil {
+		t.Fatalf("CreateSlot returned error: %v", err)
+	}
+
+	sensorCtx := newTestContext(t, roleSensor)
+	sensorCtx.SetStub(adminCtx.GetStub())
+	if err := contract.DeleteSlot(sensorCtx, "SLOT7"); err == nil {
+		t.Fatal("expected DeleteSlot to reject a caller without the admin role")
+	}
+
+	exists, err := contract.SlotExists(adminCtx, "SLOT7")
+	if err != nil {
+		t.Fatalf("SlotExists returned error: %v", err)
+	}
+	if !exists {
+		t.Fatal("expected slot to still exist after the rejected DeleteSlot call")
+	}
+}
+
+func TestAuthorizeAcceptsSensorOrgMSPWithoutRoleAttribute(t *testing.T) {
+	ctx := newTestContext(t, "")
+	ctx.SetClientIdentity(&fakeClientIdentity{mspID: sensorOrgMSP})
+
+	if err := authorize(ctx, roleSensor); err != nil {
+		t.Fatalf("expected identity from %s to be authorized as sensor, got: %v", sensorOrgMSP, err)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import "testing"
+
+func TestCreateReadUpdateDeleteSlot(t *testing.T) {
+	contract := new(SmartContract)
+	adminCtx := newTestContext(t, roleAdmin)
+	sensorCtx := newTestContext(t, roleSensor)
+	sensorCtx.SetStub(adminCtx.GetStub())
+
+	if err := contract.CreateSlot(adminCtx, "SLOT9", "Level2-B1"); err != nil {
+		t.Fatalf("CreateSlot returned error: %v", err)
+	}
+
+	if err := contract.CreateSlot(adminCtx, "SLOT9", "Level2-B1"); err == nil {
+		t.Fatal("expected CreateSlot to fail for an existing slot, got nil error")
+	}
+
+	slot, err := contract.ReadSlot(adminCtx, "SLOT9")
+	if err != nil {
+		t.Fatalf("ReadSlot returned error: %v", err)
+	}
+	if slot.Location != "Level2-B1" || slot.Occupied {
+		t.Fatalf("unexpected slot read back: %+v", slot)
+	}
+
+	if err := contract.UpdateSlot(sensorCtx, "SLOT9", "Level2-B2", true); err != nil {
+		t.Fatalf("UpdateSlot returned error: %v", err)
+	}
+	slot, err = contract.ReadSlot(adminCtx, "SLOT9")
+	if err != nil {
+		t.Fatalf("ReadSlot returned error: %v", err)
+	}
+	if slot.Location != "Level2-B2" || !slot.Occupied {
+		t.Fatalf("unexpected slot after update: %+v", slot)
+	}
+
+	if err := contract.DeleteSlot(adminCtx, "SLOT9"); err != nil {
+		t.Fatalf("DeleteSlot returned error: %v", err)
+	}
+
+	exists, err := contract.SlotExists(adminCtx, "SLOT9")
+	if err != nil {
+		t.Fatalf("SlotExists returned error: %v", err)
+	}
+	if exists {
+		t.Fatal("expected slot to no longer exist after DeleteSlot")
+	}
+}
+
+func TestUpdateSlotRejectsMissingSlot(t *testing.T) {
+	contract := new(SmartContract)
+	ctx := newTestContext(t, roleSensor)
+
+	if err := contract.UpdateSlot(ctx, "MISSING", "Nowhere", false); err == nil {
+		t.Fatal("expected UpdateSlot to fail for a slot that does not exist")
+	}
+}
+
+func TestOccupyAndRelease(t *testing.T) {
+	contract := new(SmartContract)
+	adminCtx := newTestContext(t, roleAdmin)
+	ctx := newTestContext(t, roleSensor)
+	ctx.SetStub(adminCtx.GetStub())
+
+	if err := contract.CreateSlot(adminCtx, "SLOT1", "Level1-A2"); err != nil {
+		t.Fatalf("CreateSlot returned error: %v", err)
+	}
+
+	if err := contract.Occupy(ctx, "SLOT1"); err != nil {
+		t.Fatalf("Occupy returned error: %v", err)
+	}
+
+	if err := contract.Occupy(ctx, "SLOT1"); err == nil {
+		t.Fatal("expected Occupy to reject an already-occupied slot")
+	}
+
+	if err := contract.Release(ctx, "SLOT1"); err != nil {
+		t.Fatalf("Release returned error: %v", err)
+	}
+
+	if err := contract.Release(ctx, "SLOT1"); err == nil {
+		t.Fatal("expected Release to reject an already-vacant slot")
+	}
+}
+
+func TestInitLedgerSeedsSlots(t *testing.T) {
+	contract := new(SmartContract)
+	ctx := newTestContext(t, roleAdmin)
+
+	if err := contract.InitLedger(ctx); err != nil {
+		t.Fatalf("InitLedger returned error: %v", err)
+	}
+
+	slots, err := contract.GetAllSlots(ctx)
+	if err != nil {
+		t.Fatalf("GetAllSlots returned error: %v", err)
+	}
+	if len(slots) == 0 {
+		t.Fatal("expected InitLedger to seed at least one slot")
+	}
+}
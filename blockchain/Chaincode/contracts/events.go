@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// slotStatusChangedEvent is the payload published on the "SlotStatusChanged"
+// chaincode event so off-chain consumers (gateways, dashboards, IoT
+// displays) can react to occupancy changes without polling GetAllSlots.
+type slotStatusChangedEvent struct {
+	ID        string `json:"id"`
+	Location  string `json:"location"`
+	Occupied  bool   `json:"occupied"`
+	Timestamp string `json:"timestamp"`
+	TxId      string `json:"txId"`
+}
+
+// emitSlotStatusChanged publishes a "SlotStatusChanged" event for the given
+// slot, using the transaction's own ID so listeners can correlate the event
+// with the transaction that produced it.
+func emitSlotStatusChanged(ctx contractapi.TransactionContextInterface, slot ParkingSlot) error {
+	payload, err := json.Marshal(slotStatusChangedEvent{
+		ID:        slot.ID,
+		Location:  slot.Location,
+		Occupied:  slot.Occupied,
+		Timestamp: slot.Timestamp,
+		TxId:      ctx.GetStub().GetTxID(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal SlotStatusChanged payload for slot %s: %w", slot.ID, err)
+	}
+
+	if err := ctx.GetStub().SetEvent("SlotStatusChanged", payload); err != nil {
+		return fmt.Errorf("failed to set SlotStatusChanged event for slot %s: %w", slot.ID, err)
+	}
+
+	return nil
+}
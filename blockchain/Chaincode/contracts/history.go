@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// SlotHistoryEntry describes one modification of a parking slot as recorded
+// on the ledger's history, letting operators reconstruct an occupancy
+// timeline or dispute a billing decision.
+type SlotHistoryEntry struct {
+	TxId      string       `json:"txId"`
+	Timestamp string       `json:"timestamp"`
+	IsDelete  bool         `json:"isDelete"`
+	Slot      *ParkingSlot `json:"slot"`
+}
+
+// GetSlotHistory returns every recorded modification of the slot with the
+// given ID, oldest first, as reported by the ledger's history database.
+func (s *SmartContract) GetSlotHistory(ctx contractapi.TransactionContextInterface, id string) ([]SlotHistoryEntry, error) {
+	historyIterator, err := ctx.GetStub().GetHistoryForKey(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history for slot %s: %w", id, err)
+	}
+	defer historyIterator.Close()
+
+	var history []SlotHistoryEntry
+	for historyIterator.HasNext() {
+		modification, err := historyIterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate history for slot %s: %w", id, err)
+		}
+
+		entry := SlotHistoryEntry{
+			TxId:      modification.TxId,
+			Timestamp: modification.Timestamp.AsTime().Format(time.RFC3339),
+			IsDelete:  modification.IsDelete,
+		}
+
+		if !modification.IsDelete {
+			var slot ParkingSlot
+			if err := json.Unmarshal(modification.Value, &slot); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal slot %s at tx %s: %w", id, modification.TxId, err)
+			}
+			entry.Slot = &slot
+		}
+
+		history = append(history, entry)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-protos-go/ledger/queryresult"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// historyStub wraps a MockStub to serve GetHistoryForKey from a canned set of
+// modifications, since MockStub itself does not implement history queries.
+type historyStub struct {
+	*shimtest.MockStub
+	modifications []*queryresult.KeyModification
+}
+
+func (s *historyStub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	return &fakeHistoryIterator{modifications: s.modifications}, nil
+}
+
+type fakeHistoryIterator struct {
+	modifications []*queryresult.KeyModification
+	pos           int
+}
+
+func (it *fakeHistoryIterator) HasNext() bool {
+	return it.pos < len(it.modifications)
+}
+
+func (it *fakeHistoryIterator) Next() (*queryresult.KeyModification, error) {
+	modification := it.modifications[it.pos]
+	it.pos++
+	return modification, nil
+}
+
+func (it *fakeHistoryIterator) Close() error {
+	return nil
+}
+
+func TestGetSlotHistoryRoundTrips(t *testing.T) {
+	contract := new(SmartContract)
+	ctx := newTestContext(t, roleAdmin)
+
+	created := ParkingSlot{ID: "SLOT12", Location: "Level1-E1", Occupied: false, Timestamp: "2026-01-01T00:00:00Z"}
+	createdJSON, err := json.Marshal(created)
+	if err != nil {
+		t.Fatalf("failed to marshal created slot: %v", err)
+	}
+	occupied := ParkingSlot{ID: "SLOT12", Location: "Level1-E1", Occupied: true, Timestamp: "2026-01-01T01:00:00Z"}
+	occupiedJSON, err := json.Marshal(occupied)
+	if err != nil {
+		t.Fatalf("failed to marshal occupied slot: %v", err)
+	}
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)
+
+	ctx.SetStub(&historyStub{
+		MockStub: ctx.GetStub().(*shimtest.MockStub),
+		modifications: []*queryresult.KeyModification{
+			{TxId: "tx1", Value: createdJSON, Timestamp: timestamppb.New(createdAt), IsDelete: false},
+			{TxId: "tx2", Value: occupiedJSON, Timestamp: timestamppb.New(updatedAt), IsDelete: false},
+			{TxId: "tx3", Timestamp: timestamppb.New(updatedAt), IsDelete: true},
+		},
+	})
+
+	history, err := contract.GetSlotHistory(ctx, "SLOT12")
+	if err != nil {
+		t.Fatalf("GetSlotHistory returned error: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected 3 history entries, got %d", len(history))
+	}
+
+	if history[0].TxId != "tx1" || history[0].Slot == nil || *history[0].Slot != created {
+		t.Fatalf("unexpected first history entry: %+v", history[0])
+	}
+	if history[0].Timestamp != createdAt.Format(time.RFC3339) {
+		t.Fatalf("unexpected first history timestamp: %s", history[0].Timestamp)
+	}
+
+	if history[1].TxId != "tx2" || history[1].Slot == nil || *history[1].Slot != occupied {
+		t.Fatalf("unexpected second history entry: %+v", history[1])
+	}
+
+	if !history[2].IsDelete || history[2].Slot != nil {
+		t.Fatalf("expected third history entry to record a deletion with no slot, got: %+v", history[2])
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// PaginatedSlotQueryResult wraps a page of slots returned by the
+// pagination-aware query functions below, mirroring the bookmark/record
+// count shape used by CouchDB's rich query pagination API.
+type PaginatedSlotQueryResult struct {
+	Slots          []ParkingSlot `json:"slots"`
+	FetchedRecords int32         `json:"fetchedRecords"`
+	Bookmark       string        `json:"bookmark"`
+}
+
+// QueryByJSON runs an arbitrary CouchDB selector query and returns the
+// matching slots. It requires the peer to be configured with the CouchDB
+// state database.
+func (s *SmartContract) QueryByJSON(ctx contractapi.TransactionContextInterface, queryString string) ([]ParkingSlot, error) {
+	resultsIterator, err := ctx.GetStub().GetQueryResult(queryString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query result: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	return collectSlots(resultsIterator)
+}
+
+// QueryByLocation returns every slot registered at the given location.
+func (s *SmartContract) QueryByLocation(ctx contractapi.TransactionContextInterface, location string) ([]ParkingSlot, error) {
+	selector := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"location": location,
+		},
+	}
+
+	queryBytes, err := json.Marshal(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal location query: %w", err)
+	}
+
+	return s.QueryByJSON(ctx, string(queryBytes))
+}
+
+// QueryAvailable returns every slot that is not currently occupied.
+func (s *SmartContract) QueryAvailable(ctx contractapi.TransactionContextInterface) ([]ParkingSlot, error) {
+	queryString := `{"selector":{"occupied":false}}`
+	return s.QueryByJSON(ctx, queryString)
+}
+
+// GetSlotsWithPagination returns a single page of the full slot range,
+// starting from bookmark (an empty bookmark starts from the beginning).
+func (s *SmartContract) GetSlotsWithPagination(ctx contractapi.TransactionContextInterface, pageSize int32, bookmark string) (*PaginatedSlotQueryResult, error) {
+	resultsIterator, metadata, err := ctx.GetStub().GetStateByRangeWithPagination("", "", pageSize, bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state range with pagination: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	slots, err := collectSlots(resultsIterator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedSlotQueryResult{
+		Slots:          slots,
+		FetchedRecords: metadata.FetchedRecordsCount,
+		Bookmark:       metadata.Bookmark,
+	}, nil
+}
+
+// QueryByJSONWithPagination runs a CouchDB selector query and returns a
+// single page of results, starting from bookmark.
+func (s *SmartContract) QueryByJSONWithPagination(ctx contractapi.TransactionContextInterface, queryString string, pageSize int32, bookmark string) (*PaginatedSlotQueryResult, error) {
+	resultsIterator, metadata, err := ctx.GetStub().GetQueryResultWithPagination(queryString, pageSize, bookmark)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get query result with pagination: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	slots, err := collectSlots(resultsIterator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginatedSlotQueryResult{
+		Slots:          slots,
+		FetchedRecords: metadata.FetchedRecordsCount,
+		Bookmark:       metadata.Bookmark,
+	}, nil
+}
+
+// collectSlots drains a state query iterator into a slice of ParkingSlot,
+// checking errors at every step.
+func collectSlots(resultsIterator shim.StateQueryIteratorInterface) ([]ParkingSlot, error) {
+	var slots []ParkingSlot
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate query result: %w", err)
+		}
+
+		var slot ParkingSlot
+		if err := json.Unmarshal(queryResponse.Value, &slot); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal slot %s: %w", queryResponse.Key, err)
+		}
+		slots = append(slots, slot)
+	}
+
+	return slots, nil
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// reservationKeyType is the composite-key namespace reservations are stored
+// under, keyed on (slotID, reservationID) so GetStateByPartialCompositeKey
+// can list every reservation held against a given slot.
+const reservationKeyType = "res"
+
+// ReservationStatus enumerates the lifecycle states of a Reservation.
+type ReservationStatus string
+
+const (
+	ReservationActive    ReservationStatus = "ACTIVE"
+	ReservationCancelled ReservationStatus = "CANCELLED"
+	ReservationCompleted ReservationStatus = "COMPLETED"
+	ReservationExpired   ReservationStatus = "EXPIRED"
+)
+
+// Reservation represents a time-bounded hold on a parking slot.
+type Reservation struct {
+	ID        string            `json:"id"`
+	SlotID    string            `json:"slotId"`
+	DriverID  string            `json:"driverId"`
+	StartTime string            `json:"startTime"`
+	EndTime   string            `json:"endTime"`
+	Status    ReservationStatus `json:"status"`
+}
+
+// ReserveSlot places a time-bounded hold on a slot for a driver. It fails if
+// the slot is occupied or already has an active reservation overlapping the
+// requested interval. As with CheckIn/CancelReservation, the caller must
+// either be the driver being booked for or an admin booking on their behalf.
+func (s *SmartContract) ReserveSlot(ctx contractapi.TransactionContextInterface, slotID string, driverID string, durationMinutes int) error {
+	if err := authorizeDriverOrAdmin(ctx, driverID); err != nil {
+		return err
+	}
+
+	slot, err := s.ReadSlot(ctx, slotID)
+	if err != nil {
+		return err
+	}
+	if slot.Occupied {
+		return fmt.Errorf("slot %s is occupied", slotID)
+	}
+
+	start, err := txTime(ctx)
+	if err != nil {
+		return err
+	}
+	end := start.Add(time.Duration(durationMinutes) * time.Minute)
+
+	existing, err := s.listReservationsForSlot(ctx, slotID)
+	if err != nil {
+		return err
+	}
+	for _, res := range existing {
+		if res.Status != ReservationActive {
+			continue
+		}
+		resStart, err := time.Parse(time.RFC3339, res.StartTime)
+		if err != nil {
+			return fmt.Errorf("failed to parse reservation %s start time: %w", res.ID, err)
+		}
+		resEnd, err := time.Parse(time.RFC3339, res.EndTime)
+		if err != nil {
+			return fmt.Errorf("failed to parse reservation %s end time: %w", res.ID, err)
+		}
+		if start.Before(resEnd) && resStart.Before(end) {
+			return fmt.Errorf("slot %s is already reserved for an overlapping interval by reservation %s", slotID, res.ID)
+		}
+	}
+
+	reservationID := ctx.GetStub().GetTxID()
+	reservation := Reservation{
+		ID:        reservationID,
+		SlotID:    slotID,
+		DriverID:  driverID,
+		StartTime: start.Format(time.RFC3339),
+		EndTime:   end.Format(time.RFC3339),
+		Status:    ReservationActive,
+	}
+
+	return s.putReservation(ctx, reservation)
+}
+
+// CancelReservation withdraws an active reservation without checking the
+// driver in. Like CheckIn, only the reserving driver or an admin may cancel.
+func (s *SmartContract) CancelReservation(ctx contractapi.TransactionContextInterface, slotID string, resID string) error {
+	reservation, err := s.readReservation(ctx, slotID, resID)
+	if err != nil {
+		return err
+	}
+	if reservation.Status != ReservationActive {
+		return fmt.Errorf("reservation %s is not active", resID)
+	}
+	if err := authorizeDriverOrAdmin(ctx, reservation.DriverID); err != nil {
+		return err
+	}
+
+	reservation.Status = ReservationCancelled
+	return s.putReservation(ctx, *reservation)
+}
+
+// CheckIn completes a reservation and occupies the underlying slot. Unlike
+// Occupy, it is driver-facing: the caller must either be the driver who
+// holds the reservation or an admin, not a sensor.
+func (s *SmartContract) CheckIn(ctx contractapi.TransactionContextInterface, slotID string, resID string) error {
+	reservation, err := s.readReservation(ctx, slotID, resID)
+	if err != nil {
+		return err
+	}
+	if reservation.Status != ReservationActive {
+		return fmt.Errorf("reservation %s is not active", resID)
+	}
+
+	if err := authorizeDriverOrAdmin(ctx, reservation.DriverID); err != nil {
+		return err
+	}
+
+	reservation.Status = ReservationCompleted
+	if err := s.putReservation(ctx, *reservation); err != nil {
+		return err
+	}
+
+	return s.occupySlot(ctx, slotID)
+}
+
+// SweepExpired scans every active reservation and expires the ones whose
+// EndTime is at or before the current transaction timestamp, freeing the slot for
+// new reservations.
+func (s *SmartContract) SweepExpired(ctx contractapi.TransactionContextInterface) error {
+	now, err := txTime(ctx)
+	if err != nil {
+		return err
+	}
+
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(reservationKeyType, []string{})
+	if err != nil {
+		return fmt.Errorf("failed to get reservations: %w", err)
+	}
+	defer resultsIterator.Close()
+
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return fmt.Errorf("failed to iterate reservations: %w", err)
+		}
+
+		var reservation Reservation
+		if err := json.Unmarshal(queryResponse.Value, &reservation); err != nil {
+			return fmt.Errorf("failed to unmarshal reservation %s: %w", queryResponse.Key, err)
+		}
+
+		if reservation.Status != ReservationActive {
+			continue
+		}
+
+		end, err := time.Parse(time.RFC3339, reservation.EndTime)
+		if err != nil {
+			return fmt.Errorf("failed to parse reservation %s end time: %w", reservation.ID, err)
+		}
+		if now.Before(end) {
+			continue
+		}
+
+		reservation.Status = ReservationExpired
+		reservationJSON, err := json.Marshal(reservation)
+		if err != nil {
+			return fmt.Errorf("failed to marshal reservation %s: %w", reservation.ID, err)
+		}
+		if err := ctx.GetStub().PutState(queryResponse.Key, reservationJSON); err != nil {
+			return fmt.Errorf("failed to put reservation %s: %w", reservation.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SmartContract) listReservationsForSlot(ctx contractapi.TransactionContextInterface, slotID string) ([]Reservation, error) {
+	resultsIterator, err := ctx.GetStub().GetStateByPartialCompositeKey(reservationKeyType, []string{slotID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservations for slot %s: %w", slotID, err)
+	}
+	defer resultsIterator.Close()
+
+	var reservations []Reservation
+	for resultsIterator.HasNext() {
+		queryResponse, err := resultsIterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate reservations for slot %s: %w", slotID, err)
+		}
+
+		var reservation Reservation
+		if err := json.Unmarshal(queryResponse.Value, &reservation); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal reservation %s: %w", queryResponse.Key, err)
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, nil
+}
+
+func (s *SmartContract) readReservation(ctx contractapi.TransactionContextInterface, slotID string, resID string) (*Reservation, error) {
+	key, err := ctx.GetStub().CreateCompositeKey(reservationKeyType, []string{slotID, resID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create composite key for reservation %s: %w", resID, err)
+	}
+
+	reservationJSON, err := ctx.GetStub().GetState(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reservation %s: %w", resID, err)
+	}
+	if reservationJSON == nil {
+		return nil, fmt.Errorf("reservation %s does not exist for slot %s", resID, slotID)
+	}
+
+	var reservation Reservation
+	if err := json.Unmarshal(reservationJSON, &reservation); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reservation %s: %w", resID, err)
+	}
+
+	return &reservation, nil
+}
+
+func (s *SmartContract) putReservation(ctx contractapi.TransactionContextInterface, reservation Reservation) error {
+	key, err := ctx.GetStub().CreateCompositeKey(reservationKeyType, []string{reservation.SlotID, reservation.ID})
+	if err != nil {
+		return fmt.Errorf("failed to create composite key for reservation %s: %w", reservation.ID, err)
+	}
+
+	reservationJSON, err := json.Marshal(reservation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reservation %s: %w", reservation.ID, err)
+	}
+
+	return ctx.GetStub().PutState(key, reservationJSON)
+}
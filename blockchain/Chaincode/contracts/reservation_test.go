@@ -0,0 +1,142 @@
+package main
+
+import "testing"
+
+func TestCheckInAllowsReservingDriverWithoutSensorRole(t *testing.T) {
+	contract := new(SmartContract)
+	adminCtx := newTestContext(t, roleAdmin)
+	stub := adminCtx.GetStub()
+
+	if err := contract.CreateSlot(adminCtx, "SLOT8", "Level1-D1"); err != nil {
+		t.Fatalf("CreateSlot returned error: %v", err)
+	}
+
+	driverCtx := newTestContext(t, "")
+	driverCtx.SetStub(stub)
+	driverCtx.SetClientIdentity(&fakeClientIdentity{})
+
+	if err := contract.ReserveSlot(driverCtx, "SLOT8", "test-client", 30); err != nil {
+		t.Fatalf("ReserveSlot returned error: %v", err)
+	}
+
+	reservations, err := contract.listReservationsForSlot(driverCtx, "SLOT8")
+	if err != nil {
+		t.Fatalf("listReservationsForSlot returned error: %v", err)
+	}
+	if len(reservations) != 1 {
+		t.Fatalf("expected exactly one reservation, got %d", len(reservations))
+	}
+
+	if err := contract.CheckIn(driverCtx, "SLOT8", reservations[0].ID); err != nil {
+		t.Fatalf("expected the reserving driver to check in without sensor credentials, got: %v", err)
+	}
+
+	slot, err := contract.ReadSlot(adminCtx, "SLOT8")
+	if err != nil {
+		t.Fatalf("ReadSlot returned error: %v", err)
+	}
+	if !slot.Occupied {
+		t.Fatal("expected CheckIn to occupy the slot")
+	}
+}
+
+func TestCheckInRejectsOtherDriversWithoutAdminRole(t *testing.T) {
+	contract := new(SmartContract)
+	adminCtx := newTestContext(t, roleAdmin)
+	stub := adminCtx.GetStub()
+
+	if err := contract.CreateSlot(adminCtx, "SLOT9B", "Level1-D2"); err != nil {
+		t.Fatalf("CreateSlot returned error: %v", err)
+	}
+
+	if err := contract.ReserveSlot(adminCtx, "SLOT9B", "someone-else", 30); err != nil {
+		t.Fatalf("ReserveSlot returned error: %v", err)
+	}
+
+	reservations, err := contract.listReservationsForSlot(adminCtx, "SLOT9B")
+	if err != nil {
+		t.Fatalf("listReservationsForSlot returned error: %v", err)
+	}
+	if len(reservations) != 1 {
+		t.Fatalf("expected exactly one reservation, got %d", len(reservations))
+	}
+
+	otherDriverCtx := newTestContext(t, "")
+	otherDriverCtx.SetStub(stub)
+	otherDriverCtx.SetClientIdentity(&fakeClientIdentity{})
+
+	if err := contract.CheckIn(otherDriverCtx, "SLOT9B", reservations[0].ID); err == nil {
+		t.Fatal("expected CheckIn to reject a caller who is neither the reserving driver nor an admin")
+	}
+
+	slot, err := contract.ReadSlot(adminCtx, "SLOT9B")
+	if err != nil {
+		t.Fatalf("ReadSlot returned error: %v", err)
+	}
+	if slot.Occupied {
+		t.Fatal("expected no state to be written by the rejected CheckIn call")
+	}
+}
+
+func TestReserveSlotRejectsBookingForAnotherDriverWithoutAdminRole(t *testing.T) {
+	contract := new(SmartContract)
+	adminCtx := newTestContext(t, roleAdmin)
+	stub := adminCtx.GetStub()
+
+	if err := contract.CreateSlot(adminCtx, "SLOT10", "Level1-D3"); err != nil {
+		t.Fatalf("CreateSlot returned error: %v", err)
+	}
+
+	callerCtx := newTestContext(t, "")
+	callerCtx.SetStub(stub)
+	callerCtx.SetClientIdentity(&fakeClientIdentity{})
+
+	if err := contract.ReserveSlot(callerCtx, "SLOT10", "someone-else", 30); err == nil {
+		t.Fatal("expected ReserveSlot to reject a caller booking for a different driver without the admin role")
+	}
+
+	reservations, err := contract.listReservationsForSlot(adminCtx, "SLOT10")
+	if err != nil {
+		t.Fatalf("listReservationsForSlot returned error: %v", err)
+	}
+	if len(reservations) != 0 {
+		t.Fatal("expected no reservation to be written by the rejected ReserveSlot call")
+	}
+}
+
+func TestCancelReservationRejectsOtherDriversWithoutAdminRole(t *testing.T) {
+	contract := new(SmartContract)
+	adminCtx := newTestContext(t, roleAdmin)
+	stub := adminCtx.GetStub()
+
+	if err := contract.CreateSlot(adminCtx, "SLOT11", "Level1-D4"); err != nil {
+		t.Fatalf("CreateSlot returned error: %v", err)
+	}
+	if err := contract.ReserveSlot(adminCtx, "SLOT11", "someone-else", 30); err != nil {
+		t.Fatalf("ReserveSlot returned error: %v", err)
+	}
+
+	reservations, err := contract.listReservationsForSlot(adminCtx, "SLOT11")
+	if err != nil {
+		t.Fatalf("listReservationsForSlot returned error: %v", err)
+	}
+	if len(reservations) != 1 {
+		t.Fatalf("expected exactly one reservation, got %d", len(reservations))
+	}
+
+	otherDriverCtx := newTestContext(t, "")
+	otherDriverCtx.SetStub(stub)
+	otherDriverCtx.SetClientIdentity(&fakeClientIdentity{})
+
+	if err := contract.CancelReservation(otherDriverCtx, "SLOT11", reservations[0].ID); err == nil {
+		t.Fatal("expected CancelReservation to reject a caller who is neither the reserving driver nor an admin")
+	}
+
+	reservations, err = contract.listReservationsForSlot(adminCtx, "SLOT11")
+	if err != nil {
+		t.Fatalf("listReservationsForSlot returned error: %v", err)
+	}
+	if reservations[0].Status != ReservationActive {
+		t.Fatal("expected no state to be written by the rejected CancelReservation call")
+	}
+}
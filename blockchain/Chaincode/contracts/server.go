@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// startAsServer runs the chaincode as a long-lived gRPC server (Chaincode-as-
+// a-Service) listening on address (taken from CHAINCODE_SERVER_ADDRESS), which
+// peers dial using the address in connection.json, instead of the classic
+// mode where the peer spawns and owns the chaincode process.
+// This decouples chaincode lifecycle from peer restarts.
+func startAsServer(chaincode *contractapi.ContractChaincode, address string) {
+	server := &shim.ChaincodeServer{
+		CCID:     os.Getenv("CHAINCODE_ID"),
+		Address:  address,
+		CC:       chaincode,
+		TLSProps: tlsPropertiesFromEnv(),
+	}
+
+	if err := server.Start(); err != nil {
+		fmt.Printf("Error starting parking chaincode server: %s", err.Error())
+	}
+}
+
+// tlsPropertiesFromEnv builds the chaincode server's TLS configuration from
+// CHAINCODE_TLS_* environment variables, defaulting to TLS disabled for
+// local development.
+func tlsPropertiesFromEnv() shim.TLSProperties {
+	tlsDisabled := strings.ToLower(envOrDefault("CHAINCODE_TLS_DISABLED", "true")) == "true"
+
+	var key, cert, clientCACert []byte
+
+	if !tlsDisabled {
+		key = mustReadFile(os.Getenv("CHAINCODE_TLS_KEY"))
+		cert = mustReadFile(os.Getenv("CHAINCODE_TLS_CERT"))
+	}
+
+	if clientCACertPath := os.Getenv("CHAINCODE_CLIENT_CA_CERT"); clientCACertPath != "" {
+		clientCACert = mustReadFile(clientCACertPath)
+	}
+
+	return shim.TLSProperties{
+		Disabled:      tlsDisabled,
+		Key:           key,
+		Cert:          cert,
+		ClientCACerts: clientCACert,
+	}
+}
+
+func mustReadFile(path string) []byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("Error reading chaincode server TLS file %s: %s", path, err.Error())
+		os.Exit(1)
+	}
+	return data
+}
+
+func envOrDefault(name, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok && value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/hyperledger/fabric-chaincode-go/shimtest"
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// newTestContext returns a SmartContract transaction context backed by a
+// fresh MockStub, with a transaction already started so GetTxTimestamp and
+// GetTxID behave as they would inside a real invocation.
+func newTestContext(t *testing.T, role string) *contractapi.TransactionContext {
+	t.Helper()
+
+	stub := shimtest.NewMockStub("parking", nil)
+	stub.MockTransactionStart("tx1")
+
+	ctx := &contractapi.TransactionContext{}
+	ctx.SetStub(stub)
+	ctx.SetClientIdentity(&fakeClientIdentity{role: role})
+
+	return ctx
+}
+
+// fakeClientIdentity is a minimal cid.ClientIdentity used to drive authorize
+// in tests without needing a real X.509-backed identity.
+type fakeClientIdentity struct {
+	role  string
+	mspID string
+}
+
+func (f *fakeClientIdentity) GetID() (string, error) {
+	return "test-client", nil
+}
+
+func (f *fakeClientIdentity) GetMSPID() (string, error) {
+	return f.mspID, nil
+}
+
+func (f *fakeClientIdentity) GetAttributeValue(attrName string) (string, bool, error) {
+	if attrName == "role" && f.role != "" {
+		return f.role, true, nil
+	}
+	return "", false, nil
+}
+
+func (f *fakeClientIdentity) AssertAttributeValue(attrName, attrValue string) error {
+	value, found, err := f.GetAttributeValue(attrName)
+	if err != nil {
+		return err
+	}
+	if !found || value != attrValue {
+		return &attributeMismatchError{attrName: attrName, attrValue: attrValue}
+	}
+	return nil
+}
+
+func (f *fakeClientIdentity) GetX509Certificate() (*x509.Certificate, error) {
+	return nil, nil
+}
+
+type attributeMismatchError struct {
+	attrName  string
+	attrValue string
+}
+
+func (e *attributeMismatchError) Error() string {
+	return "client does not have attribute " + e.attrName + " with value " + e.attrValue
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-contract-api-go/contractapi"
+)
+
+// txTime returns the transaction's own timestamp as a time.Time. It must be
+// used instead of time.Now() for anything written to state: time.Now() is
+// evaluated independently by every endorsing peer and would make the
+// transaction non-deterministic.
+func txTime(ctx contractapi.TransactionContextInterface) (time.Time, error) {
+	ts, err := ctx.GetStub().GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get transaction timestamp: %w", err)
+	}
+
+	return time.Unix(ts.Seconds, int64(ts.Nanos)).UTC(), nil
+}
+
+// txTimestamp returns the transaction's own timestamp, formatted the same
+// way the slot records are.
+func txTimestamp(ctx contractapi.TransactionContextInterface) (string, error) {
+	now, err := txTime(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return now.Format(time.RFC3339), nil
+}
@@ -0,0 +1,165 @@
+// Command eventlistener subscribes to the smart-parking-system chaincode's
+// "SlotStatusChanged" events via the Fabric Gateway SDK and forwards each
+// occupancy change to an MQTT topic, so IoT displays and mobile apps can
+// react in real time instead of polling GetAllSlots.
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"log"
+	"os"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/hyperledger/fabric-gateway/pkg/client"
+	"github.com/hyperledger/fabric-gateway/pkg/identity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// slotStatusChangedEvent mirrors the payload published by the chaincode's
+// SlotStatusChanged event.
+type slotStatusChangedEvent struct {
+	ID        string `json:"id"`
+	Location  string `json:"location"`
+	Occupied  bool   `json:"occupied"`
+	Timestamp string `json:"timestamp"`
+	TxId      string `json:"txId"`
+}
+
+func main() {
+	peerEndpoint := mustEnv("PEER_ENDPOINT")
+	gatewayPeer := mustEnv("GATEWAY_PEER")
+	mspID := mustEnv("MSP_ID")
+	certPath := mustEnv("CERT_PATH")
+	keyPath := mustEnv("KEY_PATH")
+	tlsCertPath := mustEnv("TLS_CERT_PATH")
+	channelName := mustEnv("CHANNEL_NAME")
+	chaincodeName := mustEnv("CHAINCODE_NAME")
+	mqttBroker := mustEnv("MQTT_BROKER_URL")
+	mqttTopic := envOrDefault("MQTT_TOPIC", "parking/slots/status")
+
+	conn, err := newGrpcConnection(tlsCertPath, gatewayPeer, peerEndpoint)
+	if err != nil {
+		log.Fatalf("failed to create gRPC connection: %v", err)
+	}
+	defer conn.Close()
+
+	id, err := newIdentity(mspID, certPath)
+	if err != nil {
+		log.Fatalf("failed to create identity: %v", err)
+	}
+
+	sign, err := newSign(keyPath)
+	if err != nil {
+		log.Fatalf("failed to create signer: %v", err)
+	}
+
+	gw, err := client.Connect(id, client.WithSign(sign), client.WithClientConnection(conn))
+	if err != nil {
+		log.Fatalf("failed to connect gateway: %v", err)
+	}
+	defer gw.Close()
+
+	network := gw.GetNetwork(channelName)
+
+	mqttClient := mqtt.NewClient(mqtt.NewClientOptions().AddBroker(mqttBroker))
+	if token := mqttClient.Connect(); token.Wait() && token.Error() != nil {
+		log.Fatalf("failed to connect to MQTT broker: %v", token.Error())
+	}
+	defer mqttClient.Disconnect(250)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := network.ChaincodeEvents(ctx, chaincodeName)
+	if err != nil {
+		log.Fatalf("failed to subscribe to chaincode events: %v", err)
+	}
+
+	log.Printf("listening for SlotStatusChanged events on channel %s", channelName)
+
+	for event := range events {
+		if event.EventName != "SlotStatusChanged" {
+			continue
+		}
+
+		var slotEvent slotStatusChangedEvent
+		if err := json.Unmarshal(event.Payload, &slotEvent); err != nil {
+			log.Printf("failed to unmarshal SlotStatusChanged payload: %v", err)
+			continue
+		}
+
+		payload, err := json.Marshal(slotEvent)
+		if err != nil {
+			log.Printf("failed to re-marshal SlotStatusChanged payload: %v", err)
+			continue
+		}
+
+		if token := mqttClient.Publish(mqttTopic, 0, false, payload); token.Wait() && token.Error() != nil {
+			log.Printf("failed to publish slot %s to MQTT: %v", slotEvent.ID, token.Error())
+		}
+	}
+}
+
+func newGrpcConnection(tlsCertPath, gatewayPeer, peerEndpoint string) (*grpc.ClientConn, error) {
+	certificatePEM, err := os.ReadFile(tlsCertPath)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := identity.CertificateFromPEM(certificatePEM)
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(certificate)
+	transportCredentials := credentials.NewClientTLSFromCert(certPool, gatewayPeer)
+
+	return grpc.Dial(peerEndpoint, grpc.WithTransportCredentials(transportCredentials))
+}
+
+func newIdentity(mspID, certPath string) (*identity.X509Identity, error) {
+	certificatePEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := identity.CertificateFromPEM(certificatePEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return identity.NewX509Identity(mspID, certificate)
+}
+
+func newSign(keyPath string) (identity.Sign, error) {
+	privateKeyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := identity.PrivateKeyFromPEM(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return identity.NewPrivateKeySign(privateKey)
+}
+
+func mustEnv(name string) string {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		log.Fatalf("required environment variable %s is not set", name)
+	}
+	return value
+}
+
+func envOrDefault(name, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok && value != "" {
+		return value
+	}
+	return fallback
+}